@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Humanize turns a struct- or form-field name like "password_confirm" or
+// "passwordConfirm" into a user-facing label like "Password confirm": it
+// splits on underscores and camelCase boundaries, lowercases everything,
+// then title-cases the first word.
+func Humanize(field string) string {
+	if len(field) == 0 {
+		return field
+	}
+
+	words := splitWords(field)
+	if len(words) == 0 {
+		return field
+	}
+
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	words[0] = strings.Title(words[0])
+
+	return strings.Join(words, " ")
+}
+
+// splitWords breaks field into words on underscores, hyphens, and
+// camelCase boundaries (a lowercase or digit followed by an uppercase
+// letter).
+func splitWords(field string) []string {
+	var words []string
+	var cur []rune
+
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+
+	runes := []rune(field)
+	for i, c := range runes {
+		switch {
+		case c == '_' || c == '-' || unicode.IsSpace(c):
+			flush()
+		case i > 0 && unicode.IsUpper(c) && !unicode.IsUpper(runes[i-1]):
+			flush()
+			cur = append(cur, c)
+		default:
+			cur = append(cur, c)
+		}
+	}
+	flush()
+
+	return words
+}
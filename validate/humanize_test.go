@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHumanize(t *testing.T) {
+	cases := []struct {
+		field string
+		want  string
+	}{
+		{"password_confirm", "Password confirm"},
+		{"passwordConfirm", "Password confirm"},
+		{"username", "Username"},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if got := Humanize(c.field); got != c.want {
+			t.Errorf("Humanize(%q) = %q, want %q", c.field, got, c.want)
+		}
+	}
+}
+
+func TestRulesErrorsPrefixesDisplayName(t *testing.T) {
+	r := Rules{Field: "password_confirm", MinLength: 8}
+
+	errs := r.Errors("short")
+	if len(errs) == 0 {
+		t.Fatal("expected an error for a too-short value, got none")
+	}
+
+	msg := errs[0].Error()
+	if want := "Password confirm"; !strings.Contains(msg, want) {
+		t.Errorf("error message %q does not contain humanized display name %q", msg, want)
+	}
+}
+
+func TestRulesErrorsPrefixesDisplayNameOverride(t *testing.T) {
+	r := Rules{Field: "password_confirm", DisplayName: "Confirmation", MinLength: 8}
+
+	errs := r.Errors("short")
+	if len(errs) == 0 {
+		t.Fatal("expected an error for a too-short value, got none")
+	}
+
+	msg := errs[0].Error()
+	if !strings.Contains(msg, "Confirmation") {
+		t.Errorf("error message %q does not contain DisplayName override %q", msg, "Confirmation")
+	}
+	if strings.Contains(msg, "Password confirm") {
+		t.Errorf("error message %q used the humanized field name instead of the DisplayName override", msg)
+	}
+}
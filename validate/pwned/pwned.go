@@ -0,0 +1,86 @@
+// Package pwned implements validate.Rules.BreachChecker against the
+// "Have I Been Pwned" Pwned Passwords range API, using the k-anonymity
+// protocol: only the first 5 hex characters of the password's SHA-1 hash
+// are ever sent over the network.
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL is the public Pwned Passwords range endpoint.
+const DefaultBaseURL = "https://api.pwnedpasswords.com/range/"
+
+// Checker implements validate.Rules.BreachChecker using the k-anonymity
+// range protocol: it hashes the password with SHA-1, sends the first 5
+// hex characters of the hash to BaseURL, and scans the returned
+// line-delimited "SUFFIX:count" response for the remaining 35 characters.
+//
+// The zero value is ready to use against the public API; set Client and/or
+// BaseURL to point at a mirror or a test server.
+type Checker struct {
+	// BaseURL is the range endpoint, including trailing slash. Defaults
+	// to DefaultBaseURL when empty.
+	BaseURL string
+	// Client is the HTTP client used to query BaseURL. Defaults to
+	// http.DefaultClient when nil.
+	Client *http.Client
+}
+
+// Check implements validate.Rules.BreachChecker's signature: it reports
+// whether password appears in the breach corpus.
+func (c Checker) Check(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned: unexpected status %s", resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(strings.TrimSpace(line), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[0] != suffix {
+			continue
+		}
+		count, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return true, nil
+		}
+		return count > 0, nil
+	}
+
+	return false, scanner.Err()
+}
@@ -0,0 +1,83 @@
+package pwned
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sha1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const (
+	passwordPrefix = "5BAA6"
+	passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+func newTestServer(t *testing.T, wantPrefix, body string, status int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Path; got != "/"+wantPrefix {
+			t.Errorf("request path = %q, want suffix %q (only the hash prefix should ever be sent)", got, wantPrefix)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestCheckerMatch(t *testing.T) {
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" +
+		passwordSuffix + ":3730471\r\n" +
+		"00D4F6E8FA6EECAD2A3AA415EEC418D38EC:2\r\n"
+	srv := newTestServer(t, passwordPrefix, body, http.StatusOK)
+	defer srv.Close()
+
+	c := Checker{BaseURL: srv.URL + "/"}
+	breached, err := c.Check(context.Background(), "password")
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !breached {
+		t.Error("Check() = false, want true for a suffix present in the range response")
+	}
+}
+
+func TestCheckerMiss(t *testing.T) {
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" +
+		"00D4F6E8FA6EECAD2A3AA415EEC418D38EC:2\r\n"
+	srv := newTestServer(t, passwordPrefix, body, http.StatusOK)
+	defer srv.Close()
+
+	c := Checker{BaseURL: srv.URL + "/"}
+	breached, err := c.Check(context.Background(), "password")
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if breached {
+		t.Error("Check() = true, want false when no line's suffix matches")
+	}
+}
+
+func TestCheckerNonOKStatus(t *testing.T) {
+	srv := newTestServer(t, passwordPrefix, "", http.StatusInternalServerError)
+	defer srv.Close()
+
+	c := Checker{BaseURL: srv.URL + "/"}
+	if _, err := c.Check(context.Background(), "password"); err == nil {
+		t.Error("Check() returned nil error, want an error for a non-200 response")
+	}
+}
+
+func TestCheckerInjectableClient(t *testing.T) {
+	body := passwordSuffix + ":1\r\n"
+	srv := newTestServer(t, passwordPrefix, body, http.StatusOK)
+	defer srv.Close()
+
+	c := Checker{BaseURL: srv.URL + "/", Client: srv.Client()}
+	breached, err := c.Check(context.Background(), "password")
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !breached {
+		t.Error("Check() = false, want true when using an injected *http.Client")
+	}
+}
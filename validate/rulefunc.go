@@ -0,0 +1,170 @@
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/authboss.v0"
+)
+
+// RuleFunc validates a single field's value. value holds the raw value
+// being validated, and form holds the full set of submitted fields so a
+// RuleFunc can reference sibling fields (for example, comparing a
+// password_confirm field against password). RuleFunc returns nil when the
+// value is valid.
+//
+// Rules.Funcs runs a slice of these alongside Rules' built-in checks, so
+// a single Rules value can mix the struct-field rules (MinLength, and so
+// on) with cross-field RuleFuncs like Equals.
+type RuleFunc func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError
+
+// Required rejects a zero-length string value.
+func Required() RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if fmt.Sprint(value) == "" {
+			return &authboss.FieldError{field, fmt.Errorf("Cannot be blank")}
+		}
+		return nil
+	}
+}
+
+// MinLen rejects strings shorter than n runes.
+func MinLen(n int) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if len([]rune(fmt.Sprint(value))) < n {
+			return &authboss.FieldError{field, fmt.Errorf("Must be at least %d characters", n)}
+		}
+		return nil
+	}
+}
+
+// MaxLen rejects strings longer than n runes.
+func MaxLen(n int) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if len([]rune(fmt.Sprint(value))) > n {
+			return &authboss.FieldError{field, fmt.Errorf("Must be at most %d characters", n)}
+		}
+		return nil
+	}
+}
+
+// Matches rejects strings that do not match re.
+func Matches(re *regexp.Regexp) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if !re.MatchString(fmt.Sprint(value)) {
+			return &authboss.FieldError{field, fmt.Errorf("Does not match required pattern")}
+		}
+		return nil
+	}
+}
+
+// OneOf rejects any value not present in vals.
+func OneOf(vals ...string) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		s := fmt.Sprint(value)
+		for _, v := range vals {
+			if s == v {
+				return nil
+			}
+		}
+		return &authboss.FieldError{field, fmt.Errorf("Must be one of: %s", strings.Join(vals, ", "))}
+	}
+}
+
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Email rejects strings that don't look like an email address.
+func Email() RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if !emailRegexp.MatchString(fmt.Sprint(value)) {
+			return &authboss.FieldError{field, fmt.Errorf("Must be a valid email address")}
+		}
+		return nil
+	}
+}
+
+// URL rejects strings that do not parse as an absolute URL.
+func URL() RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		u, err := url.Parse(fmt.Sprint(value))
+		if err != nil || !u.IsAbs() {
+			return &authboss.FieldError{field, fmt.Errorf("Must be a valid URL")}
+		}
+		return nil
+	}
+}
+
+// Equals rejects a value that does not equal the value of otherField in
+// form. This is used to validate things like password confirmation fields.
+func Equals(otherField string) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if fmt.Sprint(value) != fmt.Sprint(form[otherField]) {
+			return &authboss.FieldError{field, fmt.Errorf("Does not match %s", otherField)}
+		}
+		return nil
+	}
+}
+
+// NotEquals rejects a value that equals the value of otherField in form.
+func NotEquals(otherField string) RuleFunc {
+	return func(field string, value interface{}, form map[string]interface{}) *authboss.FieldError {
+		if fmt.Sprint(value) == fmt.Sprint(form[otherField]) {
+			return &authboss.FieldError{field, fmt.Errorf("Must not match %s", otherField)}
+		}
+		return nil
+	}
+}
+
+// Validator aggregates errors produced by running RuleFuncs across a whole
+// form, so that several fields' Rules can share one error container instead
+// of each returning its own authboss.ErrorList.
+type Validator struct {
+	errs authboss.ErrorList
+}
+
+// AddFieldError appends a field-scoped error to the validator if err is
+// non-nil.
+func (v *Validator) AddFieldError(err *authboss.FieldError) {
+	if err == nil {
+		return
+	}
+	v.errs = append(v.errs, *err)
+}
+
+// AddNonFieldError appends an error that isn't associated with any single
+// field, such as a cross-cutting form error.
+func (v *Validator) AddNonFieldError(err error) {
+	if err == nil {
+		return
+	}
+	v.errs = append(v.errs, authboss.FieldError{"", err})
+}
+
+// Valid reports whether the validator has accumulated no errors.
+func (v *Validator) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Errors returns the accumulated errors, or nil if there are none.
+func (v *Validator) Errors() authboss.ErrorList {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Funcs holds an ordered set of RuleFuncs to run against a single field's
+// value, with access to the rest of the form for cross-field rules.
+type Funcs struct {
+	Field string
+	Rules []RuleFunc
+}
+
+// Validate runs all of f.Rules against value and records any failures on v.
+func (f Funcs) Validate(v *Validator, value interface{}, form map[string]interface{}) {
+	for _, rule := range f.Rules {
+		v.AddFieldError(rule(f.Field, value, form))
+	}
+}
@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRulesFuncsPasswordConfirm(t *testing.T) {
+	r := Rules{
+		Field: "password_confirm",
+		Funcs: []RuleFunc{Equals("password")},
+	}
+
+	form := map[string]interface{}{"password": "hunter2", "password_confirm": "hunter2"}
+	if errs := r.ErrorsContextForm(context.Background(), "hunter2", form); errs != nil {
+		t.Errorf("expected no errors when password_confirm matches password, got %v", errs)
+	}
+
+	form["password_confirm"] = "wrong"
+	if errs := r.ErrorsContextForm(context.Background(), "wrong", form); errs == nil {
+		t.Error("expected an error when password_confirm does not match password, got nil")
+	}
+}
+
+func TestFuncsValidate(t *testing.T) {
+	f := Funcs{Field: "password_confirm", Rules: []RuleFunc{Equals("password")}}
+	form := map[string]interface{}{"password": "hunter2", "password_confirm": "hunter2"}
+
+	var v Validator
+	f.Validate(&v, form["password_confirm"], form)
+	if !v.Valid() {
+		t.Errorf("expected validator to be valid, got errors: %v", v.Errors())
+	}
+
+	form["password_confirm"] = "wrong"
+	v = Validator{}
+	f.Validate(&v, form["password_confirm"], form)
+	if v.Valid() {
+		t.Error("expected validator to be invalid when password_confirm does not match password")
+	}
+}
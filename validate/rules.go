@@ -1,37 +1,128 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
 
+	"golang.org/x/text/unicode/norm"
 	"gopkg.in/authboss.v0"
 )
 
+// NormalizeForm selects a Unicode normalization form to apply to a string
+// before it's validated and handed off for storage.
+type NormalizeForm int
+
+const (
+	// NormalizeNone performs no normalization.
+	NormalizeNone NormalizeForm = iota
+	// NormalizeNFC applies Unicode Normalization Form C.
+	NormalizeNFC
+	// NormalizeNFKC applies Unicode Normalization Form KC.
+	NormalizeNFKC
+)
+
 // Rules defines a ruleset by which a string can be validated.
 type Rules struct {
 	// Field is the name of the field this is intended to validate.
 	Field string
 	// MatchError describes the MustMatch regexp to a user.
-	MatchError           string
-	MustMatch            *regexp.Regexp
+	MatchError string
+	MustMatch  *regexp.Regexp
+	// DisplayName is the user-facing name for Field used to prefix error
+	// messages. If empty, Humanize(Field) is used instead.
+	DisplayName          string
 	MinLength, MaxLength int
 	MinLetters           int
 	MinNumeric           int
 	MinSymbols           int
 	AllowWhitespace      bool
+
+	// MinEntropyBits is the minimum estimated entropy (in bits) a string
+	// must have as computed by Strength. If zero, entropy is not checked.
+	MinEntropyBits float64
+	// CommonPasswordList is a set of well-known weak passwords that should
+	// be penalized heavily by Strength regardless of their raw entropy; a
+	// match makes Strength return 0, which only fails validation if
+	// MinEntropyBits is also set. Compare Denylist, which rejects outright.
+	CommonPasswordList []string
+
+	// Normalize selects a Unicode normalization form to apply to the
+	// string before validation. Defaults to NormalizeNone.
+	Normalize NormalizeForm
+
+	// Denylist is a set of forbidden strings, typically a top-10k common
+	// password list, checked case-insensitively and unconditionally
+	// rejected. Compare CommonPasswordList, which only affects the
+	// Strength score.
+	Denylist []string
+	// BreachChecker, if non-nil, is called by ErrorsContext to check
+	// whether the candidate string is known to have appeared in a
+	// breach. It should return quickly and respect ctx cancellation so
+	// that validation latency stays bounded.
+	BreachChecker func(ctx context.Context, password string) (bool, error)
+
+	// Funcs holds additional, composable RuleFuncs to run against the
+	// value after the checks above, with access to the rest of the
+	// submitted form. This is how cross-field rules like Equals (e.g.
+	// password_confirm matching password) plug into Rules.
+	Funcs []RuleFunc
+}
+
+// Normalized applies r.Normalize to s, returning s unchanged if no
+// normalization form is set. Callers should apply this to a value before
+// handing it off for storage, so that two equivalent-but-differently-encoded
+// inputs (e.g. NFC vs NFD) are persisted identically and compare equal on
+// a later login.
+func (r Rules) Normalized(s string) string {
+	switch r.Normalize {
+	case NormalizeNFC:
+		return norm.NFC.String(s)
+	case NormalizeNFKC:
+		return norm.NFKC.String(s)
+	default:
+		return s
+	}
 }
 
 // Errors returns an array of errors for each validation error that
 // is present in the given string. Returns nil if there are no errors.
+//
+// It's equivalent to calling ErrorsContext with context.Background(), so
+// Rules.BreachChecker (if set) is called with a context that is never
+// cancelled; use ErrorsContext directly to bound the breach lookup.
 func (r Rules) Errors(toValidate string) authboss.ErrorList {
+	return r.ErrorsContext(context.Background(), toValidate)
+}
+
+// ErrorsContext behaves like Errors, but passes ctx to r.BreachChecker (if
+// set) so that a breach lookup can be cancelled, e.g. when a request's
+// deadline expires. If ctx is already done, the breach check is skipped.
+//
+// It's equivalent to calling ErrorsContextForm with a nil form, so any
+// RuleFunc in r.Funcs that reads a sibling field (e.g. Equals) will see an
+// empty form; use ErrorsContextForm directly when validating as part of a
+// whole form.
+func (r Rules) ErrorsContext(ctx context.Context, toValidate string) authboss.ErrorList {
+	return r.ErrorsContextForm(ctx, toValidate, nil)
+}
+
+// ErrorsContextForm behaves like ErrorsContext, but also runs each RuleFunc
+// in r.Funcs against toValidate, giving them access to form so that
+// cross-field rules (Equals, NotEquals, ...) can compare against sibling
+// fields such as a password_confirm field.
+func (r Rules) ErrorsContextForm(ctx context.Context, toValidate string, form map[string]interface{}) authboss.ErrorList {
 	errs := make(authboss.ErrorList, 0)
 
-	ln := len(toValidate)
+	toValidate = r.Normalized(toValidate)
+
+	ln := runeLength(toValidate)
 	if ln == 0 {
 		errs = append(errs, authboss.FieldError{r.Field, errors.New("Cannot be blank")})
-		return err
+		return errs
 	}
 
 	if r.MustMatch != nil {
@@ -58,6 +149,27 @@ func (r Rules) Errors(toValidate string) authboss.ErrorList {
 		errs = append(errs, authboss.FieldError{r.Field, errors.New("No whitespace permitted")})
 	}
 
+	if r.MinEntropyBits > 0 && r.Strength(toValidate) < r.MinEntropyBits {
+		errs = append(errs, authboss.FieldError{r.Field, errors.New("Password too weak")})
+	}
+
+	if r.inDenylist(toValidate) {
+		errs = append(errs, authboss.FieldError{r.Field, errors.New("Too common, please choose another")})
+	}
+
+	if r.BreachChecker != nil && ctx.Err() == nil {
+		breached, err := r.BreachChecker(ctx, toValidate)
+		if err == nil && breached {
+			errs = append(errs, authboss.FieldError{r.Field, errors.New("This has appeared in a data breach, please choose another")})
+		}
+	}
+
+	for _, rule := range r.Funcs {
+		if fieldErr := rule(r.Field, toValidate, form); fieldErr != nil {
+			errs = append(errs, *fieldErr)
+		}
+	}
+
 	if len(errs) == 0 {
 		return nil
 	}
@@ -93,14 +205,23 @@ func (r Rules) Rules() []string {
 	return rules
 }
 
+// displayName returns r.DisplayName if set, otherwise a humanized form of
+// r.Field, for use as the subject of error messages.
+func (r Rules) displayName() string {
+	if len(r.DisplayName) > 0 {
+		return r.DisplayName
+	}
+	return Humanize(r.Field)
+}
+
 func (r Rules) lengthErr() (err string) {
 	switch {
 	case r.MinLength > 0 && r.MaxLength > 0:
-		err = fmt.Sprintf("Must be between %d and %d characters", r.MinLength, r.MaxLength)
+		err = fmt.Sprintf("%s must be between %d and %d characters", r.displayName(), r.MinLength, r.MaxLength)
 	case r.MinLength > 0:
-		err = fmt.Sprintf("Must be at least %d characters", r.MinLength)
+		err = fmt.Sprintf("%s must be at least %d characters", r.displayName(), r.MinLength)
 	case r.MaxLength > 0:
-		err = fmt.Sprintf("Must be at most %d characters", r.MaxLength)
+		err = fmt.Sprintf("%s must be at most %d characters", r.displayName(), r.MaxLength)
 	}
 
 	return err
@@ -108,25 +229,63 @@ func (r Rules) lengthErr() (err string) {
 
 func (r Rules) charErr() (err string) {
 	if r.MinLetters > 0 {
-		err = fmt.Sprintf("Must contain at least %d letters", r.MinLetters)
+		err = fmt.Sprintf("%s must contain at least %d letters", r.displayName(), r.MinLetters)
 	}
 	return err
 }
 
 func (r Rules) numericErr() (err string) {
 	if r.MinNumeric > 0 {
-		err = fmt.Sprintf("Must contain at least %d numbers", r.MinNumeric)
+		err = fmt.Sprintf("%s must contain at least %d numbers", r.displayName(), r.MinNumeric)
 	}
 	return err
 }
 
 func (r Rules) symbolErr() (err string) {
 	if r.MinSymbols > 0 {
-		err = fmt.Sprintf("Must contain at least %d symbols", r.MinSymbols)
+		err = fmt.Sprintf("%s must contain at least %d symbols", r.displayName(), r.MinSymbols)
 	}
 	return err
 }
 
+// inDenylist reports whether s (case-insensitively) appears in r.Denylist.
+func (r Rules) inDenylist(s string) bool {
+	return containsFold(r.Denylist, s)
+}
+
+// containsFold reports whether s appears in list under a case-insensitive
+// comparison. It backs both Rules.inDenylist and Rules.Strength's
+// CommonPasswordList check: the two lists serve different purposes (Denylist
+// hard-rejects the value outright, while CommonPasswordList only zeroes the
+// entropy score so it still flows through MinEntropyBits like any other weak
+// password) but both are plain case-insensitive membership checks, so they
+// share this helper rather than duplicating the loop.
+func containsFold(list []string, s string) bool {
+	lower := strings.ToLower(s)
+	for _, item := range list {
+		if lower == strings.ToLower(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// runeLength counts the user-perceived characters in s for MinLength and
+// MaxLength purposes. Combining marks and format characters are excluded,
+// matching tallyCharacters, so that an NFD string (base character followed
+// by combining marks) and its NFC equivalent are counted the same way
+// regardless of r.Normalize.
+func runeLength(s string) (ln int) {
+	for _, c := range s {
+		switch {
+		case unicode.Is(unicode.Mn, c), unicode.Is(unicode.Mc, c), unicode.Is(unicode.Me, c), unicode.Is(unicode.Cf, c):
+		default:
+			ln++
+		}
+	}
+	return ln
+}
+
 func tallyCharacters(s string) (chars, numeric, symbols, whitespace int) {
 	for _, c := range s {
 		switch {
@@ -136,6 +295,10 @@ func tallyCharacters(s string) (chars, numeric, symbols, whitespace int) {
 			numeric++
 		case unicode.IsSpace(c):
 			whitespace++
+		case unicode.Is(unicode.Mn, c), unicode.Is(unicode.Mc, c), unicode.Is(unicode.Me, c), unicode.Is(unicode.Cf, c):
+			// Combining marks and format characters don't count as a
+			// user-perceived symbol on their own; they modify the base
+			// character they're attached to.
 		default:
 			symbols++
 		}
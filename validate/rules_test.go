@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestTallyCharactersCombiningMarks(t *testing.T) {
+	nfc := "café"
+	nfd := norm.NFD.String(nfc)
+
+	ncChars, _, ncSymbols, _ := tallyCharacters(nfc)
+	ndChars, _, ndSymbols, _ := tallyCharacters(nfd)
+
+	if ncChars != ndChars {
+		t.Errorf("letter count differs between NFC and NFD forms of the same string: NFC=%d NFD=%d", ncChars, ndChars)
+	}
+	if ncSymbols != ndSymbols {
+		t.Errorf("symbol count differs between NFC and NFD forms of the same string: NFC=%d NFD=%d", ncSymbols, ndSymbols)
+	}
+	if ndSymbols != 0 {
+		t.Errorf("NFD combining accent counted as a symbol: got %d, want 0", ndSymbols)
+	}
+}
+
+func TestRuneLengthCombiningMarks(t *testing.T) {
+	nfc := "café"
+	nfd := norm.NFD.String(nfc)
+
+	if ncLen, ndLen := runeLength(nfc), runeLength(nfd); ncLen != ndLen {
+		t.Errorf("runeLength differs between NFC and NFD forms of the same string: NFC=%d NFD=%d", ncLen, ndLen)
+	}
+}
+
+func TestRulesMinLengthNFDBoundary(t *testing.T) {
+	// "café" is 4 user-perceived characters whether it arrives as NFC (4
+	// runes) or NFD (5 runes, the trailing "e" + combining acute accent).
+	// A MinLength of 4 should accept both under the default NormalizeNone,
+	// since length is judged the same way tallyCharacters counts symbols.
+	r := Rules{Field: "username", MinLength: 4}
+
+	nfd := norm.NFD.String("café")
+	if errs := r.Errors(nfd); errs != nil {
+		t.Errorf("Errors(%q) (NFD) = %v, want no length error", nfd, errs)
+	}
+}
+
+func TestRulesNormalized(t *testing.T) {
+	r := Rules{Field: "username", Normalize: NormalizeNFC}
+
+	nfd := norm.NFD.String("café")
+	got := r.Normalized(nfd)
+	want := norm.NFC.String(nfd)
+
+	if got != want {
+		t.Errorf("Normalized() = %q, want %q", got, want)
+	}
+}
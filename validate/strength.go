@@ -0,0 +1,140 @@
+package validate
+
+import "math"
+
+const (
+	poolLower      = 26
+	poolUpper      = 26
+	poolDigits     = 10
+	poolSymbols    = 32
+	poolNonASCII   = 256
+	sequenceWindow = 3
+	repeatWindow   = 3
+)
+
+// Strength estimates the entropy of pw in bits using a Shannon-style
+// calculation: the character pool size is derived from the classes of
+// characters actually present, and the raw bit count is then penalized for
+// common weaknesses such as sequential runs, repeated characters, and
+// membership in r.CommonPasswordList. The result is suitable for comparison
+// against Rules.MinEntropyBits, or for rendering a strength meter.
+func (r Rules) Strength(pw string) float64 {
+	if len(pw) == 0 {
+		return 0
+	}
+
+	if containsFold(r.CommonPasswordList, pw) {
+		return 0
+	}
+
+	pool := poolForRunes(pw)
+	if pool == 0 {
+		return 0
+	}
+
+	runes := []rune(pw)
+	bits := float64(len(runes)) * log2(float64(pool))
+
+	bits -= sequencePenalty(runes)
+	bits -= repeatPenalty(runes)
+
+	if bits < 0 {
+		bits = 0
+	}
+	return bits
+}
+
+// poolForRunes determines the effective character pool size a password
+// draws from, based on which classes of characters are present.
+func poolForRunes(pw string) int {
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasNonASCII bool
+
+	for _, c := range pw {
+		switch {
+		case c > unicode7BitMax:
+			hasNonASCII = true
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	pool := 0
+	if hasLower {
+		pool += poolLower
+	}
+	if hasUpper {
+		pool += poolUpper
+	}
+	if hasDigit {
+		pool += poolDigits
+	}
+	if hasSymbol {
+		pool += poolSymbols
+	}
+	if hasNonASCII {
+		pool += poolNonASCII
+	}
+	return pool
+}
+
+const unicode7BitMax = rune(127)
+
+// sequencePenalty subtracts bits for ascending or descending sequential
+// runs like "abc" or "321" of length sequenceWindow or more.
+func sequencePenalty(runes []rune) float64 {
+	var penalty float64
+	run := 1
+	var dir rune
+	for i := 1; i < len(runes); i++ {
+		step := runes[i] - runes[i-1]
+		isStep := step == 1 || step == -1
+		if isStep && (run == 1 || step == dir) {
+			run++
+		} else {
+			if run >= sequenceWindow {
+				penalty += float64(run) * log2(float64(poolLower))
+			}
+			run = 1
+			if isStep {
+				// A change of direction starts a new run at the previous rune.
+				run = 2
+			}
+		}
+		dir = step
+	}
+	if run >= sequenceWindow {
+		penalty += float64(run) * log2(float64(poolLower))
+	}
+	return penalty
+}
+
+// repeatPenalty subtracts bits for runs of the same character repeated
+// repeatWindow or more times, e.g. "aaaa".
+func repeatPenalty(runes []rune) float64 {
+	var penalty float64
+	run := 1
+	for i := 1; i < len(runes); i++ {
+		if runes[i] == runes[i-1] {
+			run++
+		} else {
+			if run >= repeatWindow {
+				penalty += float64(run) * log2(float64(poolLower))
+			}
+			run = 1
+		}
+	}
+	if run >= repeatWindow {
+		penalty += float64(run) * log2(float64(poolLower))
+	}
+	return penalty
+}
+
+func log2(n float64) float64 {
+	return math.Log(n) / math.Log(2)
+}
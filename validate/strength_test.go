@@ -0,0 +1,59 @@
+package validate
+
+import "testing"
+
+func TestStrengthCommonPassword(t *testing.T) {
+	r := Rules{CommonPasswordList: []string{"password", "hunter2"}}
+
+	if got := r.Strength("password"); got != 0 {
+		t.Errorf("Strength(%q) = %v, want 0 for a listed common password", "password", got)
+	}
+	if got := r.Strength("PaSsWoRd"); got != 0 {
+		t.Errorf("Strength(%q) = %v, want 0 for a case-insensitive common password match", "PaSsWoRd", got)
+	}
+	if got := r.Strength("not-in-the-list"); got == 0 {
+		t.Errorf("Strength(%q) = 0, want a non-zero score for a password not on the list", "not-in-the-list")
+	}
+}
+
+func TestStrengthSequencePenalty(t *testing.T) {
+	sequential := "abc123!"
+	shuffled := "a1c3b2!" // same characters, no ascending/descending runs
+
+	seqScore := Rules{}.Strength(sequential)
+	shuffledScore := Rules{}.Strength(shuffled)
+
+	if seqScore >= shuffledScore {
+		t.Errorf("Strength(%q) = %v, want less than Strength(%q) = %v (sequential run should be penalized)",
+			sequential, seqScore, shuffled, shuffledScore)
+	}
+}
+
+func TestStrengthRepeatPenalty(t *testing.T) {
+	repeated := "aaaaaaaa"
+	varied := "bdgkmqtx" // same length and pool, no repeats or runs
+
+	repeatedScore := Rules{}.Strength(repeated)
+	variedScore := Rules{}.Strength(varied)
+
+	if repeatedScore >= variedScore {
+		t.Errorf("Strength(%q) = %v, want less than Strength(%q) = %v (repeated run should be penalized)",
+			repeated, repeatedScore, varied, variedScore)
+	}
+}
+
+func TestStrengthNonASCIIBumpsPool(t *testing.T) {
+	ascii := poolForRunes("abc")
+	nonASCII := poolForRunes("abç")
+
+	if nonASCII <= ascii {
+		t.Errorf("poolForRunes with a non-ASCII rune = %d, want greater than ASCII-only pool %d", nonASCII, ascii)
+	}
+
+	var r Rules
+	asciiScore := r.Strength("abc")
+	nonASCIIScore := r.Strength("abç")
+	if nonASCIIScore <= asciiScore {
+		t.Errorf("Strength(%q) = %v, want greater than Strength(%q) = %v", "abç", nonASCIIScore, "abc", asciiScore)
+	}
+}